@@ -0,0 +1,164 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// compositionKind identifies one of the three schema
+// composition keywords supported on a struct field.
+type compositionKind int
+
+const (
+	compositionOneOf compositionKind = iota
+	compositionAnyOf
+	compositionAllOf
+)
+
+// compositionTags maps each composition kind to the
+// struct tag that triggers it.
+var compositionTags = map[compositionKind]string{
+	compositionOneOf: "oneof",
+	compositionAnyOf: "anyof",
+	compositionAllOf: "allof",
+}
+
+// oneOfImpls records, for a registered interface type, the
+// concrete implementations that may appear in its place.
+type oneOfImpls struct {
+	kind  compositionKind
+	impls []reflect.Type
+}
+
+// RegisterOneOf records the set of concrete types that
+// implement iface, so that struct fields typed as iface are
+// expanded into a oneOf schema of those types without any
+// tag. Fields of other types (json.RawMessage, for dynamically
+// typed payloads) are expanded only when tagged with
+// `oneof:"TypeA,TypeB,..."` (or anyof/allof).
+//
+// iface must be an interface type. Each of impls must
+// implement it. RegisterOneOf panics if either condition
+// does not hold, since it is only ever called from package
+// init code, not from request handling.
+func (g *Generator) RegisterOneOf(iface reflect.Type, impls ...reflect.Type) {
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("openapi: RegisterOneOf: %s is not an interface type", iface))
+	}
+	for _, impl := range impls {
+		t := impl
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if !impl.Implements(iface) && !reflect.PtrTo(t).Implements(iface) {
+			panic(fmt.Sprintf("openapi: RegisterOneOf: %s does not implement %s", impl, iface))
+		}
+	}
+	if g.oneOfRegistry == nil {
+		g.oneOfRegistry = make(map[reflect.Type][]reflect.Type)
+	}
+	g.oneOfRegistry[iface] = impls
+}
+
+// compositionSchema builds a oneOf/anyOf/allOf schema for a
+// struct field tagged with one of the composition tags, or
+// for an interface-typed/json.RawMessage field whose
+// implementations were recorded with RegisterOneOf.
+//
+// It returns nil, false when the field carries none of the
+// composition tags and its type was not registered, meaning
+// the caller should fall back to the regular field handling.
+func (g *Generator) compositionSchema(sf reflect.StructField, parent reflect.Type) (*SchemaOrRef, bool) {
+	kind, names, ok := compositionTagOf(sf)
+	if !ok {
+		impls, found := g.oneOfRegistry[sf.Type]
+		if !found {
+			return nil, false
+		}
+		kind = compositionOneOf
+		for _, impl := range impls {
+			names = append(names, g.typeName(impl))
+		}
+	}
+	members := make([]*SchemaOrRef, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		t := g.typeOfName(name)
+		if t == nil {
+			g.errors = append(g.errors, fmt.Errorf(
+				"openapi: %s.%s: unknown type %q in %s tag", parent, sf.Name, name, compositionTags[kind],
+			))
+			continue
+		}
+		if sor := g.newSchemaFromType(t); sor != nil {
+			members = append(members, sor)
+		}
+	}
+	s := &Schema{}
+	switch kind {
+	case compositionAnyOf:
+		s.AnyOf = members
+	case compositionAllOf:
+		s.AllOf = members
+	default:
+		s.OneOf = members
+	}
+	if disc, ok := sf.Tag.Lookup("discriminator"); ok {
+		s.Discriminator = parseDiscriminator(disc)
+	}
+	return &SchemaOrRef{Schema: s}, true
+}
+
+// compositionTagOf reports the composition kind and the
+// comma-separated list of type names carried by whichever
+// of the oneof/anyof/allof tags is present on sf.
+func compositionTagOf(sf reflect.StructField) (compositionKind, []string, bool) {
+	for kind, tag := range compositionTags {
+		if v, ok := sf.Tag.Lookup(tag); ok {
+			return kind, strings.Split(v, ","), true
+		}
+	}
+	return 0, nil, false
+}
+
+// typeOfName resolves a type name, as used in a oneof/anyof/
+// allof tag, back to its reflect.Type. Names are matched
+// against types previously seen by the generator, either
+// through OverrideTypeName or through their natural Go name.
+func (g *Generator) typeOfName(name string) reflect.Type {
+	if t, ok := g.namedTypes[name]; ok {
+		return t
+	}
+	return nil
+}
+
+// parseDiscriminator parses a `discriminator:"field=type,mapping=A:#/components/schemas/A"`
+// tag value into an OpenAPI Discriminator object.
+func parseDiscriminator(tag string) *Discriminator {
+	d := &Discriminator{Mapping: make(map[string]string)}
+	for _, part := range strings.Split(tag, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "field":
+			d.PropertyName = kv[1]
+		case "mapping":
+			for _, m := range strings.Split(kv[1], ";") {
+				mkv := strings.SplitN(m, ":", 2)
+				if len(mkv) == 2 {
+					d.Mapping[mkv[0]] = mkv[1]
+				}
+			}
+		}
+	}
+	if d.PropertyName == "" && len(d.Mapping) == 0 {
+		return nil
+	}
+	return d
+}
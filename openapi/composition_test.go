@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	shape interface{ Area() float64 }
+
+	square struct {
+		Side float64 `json:"side"`
+	}
+	circle struct {
+		Radius float64 `json:"radius"`
+	}
+)
+
+func (square) Area() float64 { return 0 }
+func (circle) Area() float64 { return 0 }
+
+// TestRegisterOneOf tests that implementations of an
+// interface can be recorded, and that registering a type
+// which does not implement the interface panics.
+func TestRegisterOneOf(t *testing.T) {
+	g := gen(t)
+
+	g.RegisterOneOf(reflect.TypeOf((*shape)(nil)).Elem(), rt(square{}), rt(circle{}))
+	assert.Len(t, g.oneOfRegistry, 1)
+
+	assert.Panics(t, func() {
+		g.RegisterOneOf(reflect.TypeOf((*shape)(nil)).Elem(), rt(struct{}{}))
+	})
+}
+
+// TestCompositionTagOneOf tests that a field tagged with
+// oneof is expanded into a oneOf schema listing each named
+// type's component schema.
+func TestCompositionTagOneOf(t *testing.T) {
+	type Container struct {
+		Payload interface{} `oneof:"square,circle"`
+	}
+	g := gen(t)
+	g.UseFullSchemaNames(false)
+	g.OverrideTypeName(rt(square{}), "square")
+	g.OverrideTypeName(rt(circle{}), "circle")
+
+	typ := reflect.TypeOf(Container{})
+	sor, ok := g.compositionSchema(typ.Field(0), typ)
+	assert.True(t, ok)
+	assert.NotNil(t, sor.Schema)
+	assert.Len(t, sor.OneOf, 2)
+}
+
+// TestCompositionTagUnknownType tests that an unknown type
+// name in a composition tag is reported as an error but does
+// not prevent the rest of the schema from being built.
+func TestCompositionTagUnknownType(t *testing.T) {
+	type Container struct {
+		Payload interface{} `oneof:"square,doesnotexist"`
+	}
+	g := gen(t)
+	g.UseFullSchemaNames(false)
+	g.OverrideTypeName(rt(square{}), "square")
+
+	typ := reflect.TypeOf(Container{})
+	sor, ok := g.compositionSchema(typ.Field(0), typ)
+	assert.True(t, ok)
+	assert.Len(t, sor.OneOf, 1)
+	assert.Len(t, g.Errors(), 1)
+}
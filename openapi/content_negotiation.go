@@ -0,0 +1,72 @@
+package openapi
+
+import "fmt"
+
+// defaultProducers are the content types every operation
+// accepts out of the box. RegisterProducer can only add to
+// them; they are always part of producersFor's result.
+var defaultProducers = []string{"application/json"}
+
+// RegisterProducer adds contentType to the set of response
+// content types setOperationResponse considers for opID, on
+// top of application/json. Passing an empty opID registers
+// the content type as an addition for every operation, on
+// top of whichever content types that operation registered
+// for itself.
+//
+// This lets handlers that marshal to XML or CBOR, for
+// instance, be described accurately without Fizz assuming
+// every response body is JSON.
+func (g *Generator) RegisterProducer(opID, contentType string) {
+	if g.producers == nil {
+		g.producers = make(map[string][]string)
+	}
+	g.producers[opID] = append(g.producers[opID], contentType)
+}
+
+// producersFor returns the ordered list of content types a
+// response body should be registered under for opID: always
+// defaultProducers, plus whatever was registered globally
+// (opID == "") and for opID specifically, in that order and
+// without duplicates.
+func (g *Generator) producersFor(opID string) []string {
+	types := append([]string{}, defaultProducers...)
+	types = append(types, g.producers[""]...)
+	if opID != "" {
+		types = append(types, g.producers[opID]...)
+	}
+	return dedupeStrings(types)
+}
+
+// dedupeStrings returns in, stripped of any value that
+// already occurred earlier in the slice, preserving order.
+func dedupeStrings(in []string) []string {
+	seen := make(map[string]bool, len(in))
+	out := in[:0]
+	for _, s := range in {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	return out
+}
+
+func newUnsupportedStreamKindError(kind StreamKind) error {
+	return fmt.Errorf("openapi: unsupported streaming response kind %q", kind)
+}
+
+func newUnsupportedModelError(t interface{ String() string }) error {
+	return fmt.Errorf("openapi: cannot build schema for model of type %s", t)
+}
+
+// defaultStatusCode returns the status code under which a
+// streaming operation's response should be registered,
+// falling back to "200" when infos does not specify one.
+func defaultStatusCode(infos *OperationInfo) string {
+	if infos != nil && infos.StatusCode != 0 {
+		return fmt.Sprintf("%d", infos.StatusCode)
+	}
+	return "200"
+}
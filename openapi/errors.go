@@ -0,0 +1,37 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FieldError represents an error that occurred while
+// generating the schema/parameter for a struct field.
+type FieldError struct {
+	Name              string
+	TypeName          string
+	Type              reflect.Type
+	Message           string
+	ParameterLocation string
+	Parent            reflect.Type
+}
+
+// Error implements the error interface for FieldError.
+func (e *FieldError) Error() string {
+	if e.ParameterLocation != "" {
+		return fmt.Sprintf("field %s (%s) of %s: %s", e.Name, e.ParameterLocation, e.Parent, e.Message)
+	}
+	return fmt.Sprintf("field %s of %s: %s", e.Name, e.Parent, e.Message)
+}
+
+// TypeError represents an error that occurred while
+// generating the schema for a type.
+type TypeError struct {
+	Message string
+	Type    reflect.Type
+}
+
+// Error implements the error interface for TypeError.
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("type %s: %s", e.Type, e.Message)
+}
@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SchemaHook is called after a Schema has been built from a
+// Go type, and may mutate it in place to add, override or
+// strip any of its properties.
+type SchemaHook func(t reflect.Type, s *Schema)
+
+// FieldHook is called after a Schema has been built from a
+// single struct field, and may mutate it in place.
+type FieldHook func(f reflect.StructField, s *Schema)
+
+// AddSchemaHook registers a hook invoked on every schema
+// generated from a Go struct type, after the built-in tag
+// processing and after vendor extension tags have been
+// applied, so hooks always have the final say. Schemas of
+// non-struct types (scalars, slices, maps) are not passed
+// to schema hooks.
+func (g *Generator) AddSchemaHook(hook SchemaHook) {
+	g.schemaHooks = append(g.schemaHooks, hook)
+}
+
+// AddFieldHook registers a hook invoked on every schema
+// generated from a struct field, after the built-in tag
+// processing and after vendor extension tags have been
+// applied.
+func (g *Generator) AddFieldHook(hook FieldHook) {
+	g.fieldHooks = append(g.fieldHooks, hook)
+}
+
+// runSchemaHooks applies every registered SchemaHook to s,
+// in registration order.
+func (g *Generator) runSchemaHooks(t reflect.Type, s *Schema) {
+	for _, hook := range g.schemaHooks {
+		hook(t, s)
+	}
+}
+
+// runFieldHooks applies the vendor extension tag, then every
+// registered FieldHook, to s, in that order, so that hooks
+// can still override whatever the x-* tag set.
+func (g *Generator) runFieldHooks(f reflect.StructField, s *Schema) {
+	applyVendorExtensions(f, s)
+	for _, hook := range g.fieldHooks {
+		hook(f, s)
+	}
+}
+
+// vendorExtensionTag is the struct tag used to declare
+// OpenAPI vendor extensions on a field, e.g.
+// `openapi:"x-nullable=true,x-internal=true"`.
+const vendorExtensionTag = "openapi"
+
+// applyVendorExtensions parses the openapi tag on f, if
+// present, and copies each x-* entry into s.Extensions. A
+// handful of extensions recognized by go-swagger's resolver
+// are additionally reflected onto their native Schema field
+// so that consumers that don't look at Extensions still see
+// the right behavior.
+func applyVendorExtensions(f reflect.StructField, s *Schema) {
+	tag, ok := f.Tag.Lookup(vendorExtensionTag)
+	if !ok || tag == "" {
+		return
+	}
+	for _, part := range strings.Split(tag, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		var val interface{} = true
+		if len(kv) == 2 {
+			val = parseExtensionValue(kv[1])
+		}
+		if s.Extensions == nil {
+			s.Extensions = make(map[string]interface{})
+		}
+		s.Extensions[key] = val
+
+		switch key {
+		case "x-isnullable", "x-nullable":
+			if b, ok := val.(bool); ok {
+				s.Nullable = b
+			}
+		case "x-internal":
+			// No native Schema field models this; it only
+			// ever surfaces through Extensions.
+		}
+	}
+}
+
+// parseExtensionValue converts the raw string value of an
+// x-* tag entry into a bool, an int64 or a string, in that
+// order of preference, matching the loose typing used by
+// existing tags like enum or default.
+func parseExtensionValue(raw string) interface{} {
+	if b, err := strconv.ParseBool(raw); err == nil {
+		return b
+	}
+	if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return i
+	}
+	return raw
+}
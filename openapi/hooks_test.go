@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSchemaHook tests that a registered SchemaHook is
+// called with the struct type and can mutate the schema
+// generated for it.
+func TestSchemaHook(t *testing.T) {
+	g := gen(t)
+
+	var seen reflect.Type
+	g.AddSchemaHook(func(typ reflect.Type, s *Schema) {
+		seen = typ
+		s.Title = "overridden"
+	})
+	sor := g.newSchemaFromType(rt(new(X)))
+	schema := g.resolveSchema(sor)
+
+	assert.Equal(t, rt(X{}), seen)
+	assert.Equal(t, "overridden", schema.Title)
+}
+
+// TestFieldHookAndVendorExtensions tests that the openapi
+// vendor extension tag populates Extensions, that a handful
+// of well-known keys are reflected onto native fields, and
+// that a registered FieldHook runs afterward and has the
+// final say.
+func TestFieldHookAndVendorExtensions(t *testing.T) {
+	type T struct {
+		A string `openapi:"x-nullable=true,x-internal=true"`
+	}
+	g := gen(t)
+
+	var calls int
+	g.AddFieldHook(func(f reflect.StructField, s *Schema) {
+		calls++
+		s.Extensions["x-go-name"] = f.Name
+	})
+
+	typ := reflect.TypeOf(T{})
+	sor := g.newSchemaFromStructField(typ.Field(0), false, "A", typ)
+	schema := g.resolveSchema(sor)
+
+	assert.Equal(t, 1, calls)
+	assert.True(t, schema.Nullable)
+	assert.Equal(t, true, schema.Extensions["x-internal"])
+	assert.Equal(t, "A", schema.Extensions["x-go-name"])
+}
@@ -0,0 +1,283 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect produced
+// by EmitJSONSchema and EmitJSONSchemas.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is a standalone JSON Schema document, as
+// opposed to an OpenAPI Schema Object that only has a
+// meaning relative to the OpenAPI document it belongs to.
+//
+// It embeds Schema to reuse its fields and its JSON
+// marshaling, and adds the properties that make the
+// document self-describing and referenceable on its own.
+type JSONSchema struct {
+	*Schema
+
+	// DollarSchema identifies the dialect this document
+	// is written against.
+	DollarSchema string `json:"$schema"`
+
+	// ID is the canonical URI of this schema. EmitJSONSchemas
+	// sets it to a URN built from the component name;
+	// EmitJSONSchema leaves it empty.
+	ID string `json:"$id,omitempty"`
+
+	// Defs holds the schemas of every type referenced by
+	// this document, keyed by their component name. $ref
+	// values that pointed to #/components/schemas/X in the
+	// OpenAPI document are rewritten to #/$defs/X.
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler for JSONSchema. It
+// can't rely on the default struct marshaling, since the
+// embedded *Schema's own MarshalJSON would be promoted and
+// take over entirely, silently dropping $schema/$id/$defs.
+func (j *JSONSchema) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(j.Schema)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		m = make(map[string]json.RawMessage)
+	}
+	sb, err := json.Marshal(j.DollarSchema)
+	if err != nil {
+		return nil, err
+	}
+	m["$schema"] = sb
+
+	if j.ID != "" {
+		ib, err := json.Marshal(j.ID)
+		if err != nil {
+			return nil, err
+		}
+		m["$id"] = ib
+	}
+	if len(j.Defs) > 0 {
+		db, err := json.Marshal(j.Defs)
+		if err != nil {
+			return nil, err
+		}
+		m["$defs"] = db
+	}
+	return json.Marshal(m)
+}
+
+// EmitJSONSchema generates a standalone JSON Schema (draft
+// 2020-12) document describing t. Any type referenced by
+// t through a $ref in the OpenAPI document is inlined as
+// an entry of the returned document's $defs map, and all
+// matching $ref values are rewritten accordingly.
+//
+// t is introspected with newSchemaFromType, so t and the
+// named types it references are registered in the generator's
+// components as a side effect, if they were not already.
+func (g *Generator) EmitJSONSchema(t reflect.Type) ([]byte, error) {
+	sor := g.newSchemaFromType(t)
+	if sor == nil {
+		return nil, fmt.Errorf("openapi: cannot emit JSON Schema for type %s", t)
+	}
+	js := &JSONSchema{
+		DollarSchema: jsonSchemaDraft,
+		Defs:         make(map[string]*Schema),
+	}
+	if sor.Ref != "" {
+		name := componentsSchemaName(sor.Ref)
+		js.Schema = &Schema{Ref: jsonSchemaRef(name)}
+	} else {
+		// sor is an inline schema (e.g. a top-level slice or map
+		// type), so unlike a $defs entry it is never passed
+		// through rewriteSchemaRefs by collectJSONSchemaDefs;
+		// do it here so its own nested $refs point at $defs too.
+		js.Schema = rewriteSchemaRefs(sor.Schema)
+	}
+	g.collectJSONSchemaDefs(sor, js.Defs, make(map[string]bool))
+
+	return json.Marshal(js)
+}
+
+// EmitJSONSchemas generates a standalone JSON Schema document
+// for every schema currently registered in Components.Schemas.
+// The returned map is keyed by component name.
+func (g *Generator) EmitJSONSchemas() (map[string][]byte, error) {
+	docs := make(map[string][]byte, len(g.api.Components.Schemas))
+
+	for name, sor := range g.api.Components.Schemas {
+		js := &JSONSchema{
+			DollarSchema: jsonSchemaDraft,
+			ID:           jsonSchemaID(name),
+			Defs:         make(map[string]*Schema),
+		}
+		if sor.Ref != "" {
+			js.Schema = &Schema{Ref: jsonSchemaRef(componentsSchemaName(sor.Ref))}
+		} else {
+			js.Schema = rewriteSchemaRefs(sor.Schema)
+		}
+		g.collectJSONSchemaDefs(sor, js.Defs, map[string]bool{name: true})
+
+		b, err := json.Marshal(js)
+		if err != nil {
+			return nil, fmt.Errorf("openapi: cannot marshal JSON Schema for %s: %w", name, err)
+		}
+		docs[name] = b
+	}
+	return docs, nil
+}
+
+// collectJSONSchemaDefs walks sor and every schema it
+// transitively references, rewriting OpenAPI component
+// refs to JSON Schema $defs refs and recording each
+// referenced schema in defs. seen prevents infinite
+// recursion on cyclic types.
+func (g *Generator) collectJSONSchemaDefs(sor *SchemaOrRef, defs map[string]*Schema, seen map[string]bool) {
+	if sor == nil {
+		return
+	}
+	if sor.Ref != "" {
+		name := componentsSchemaName(sor.Ref)
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+
+		ref, ok := g.api.Components.Schemas[name]
+		if !ok || ref.Schema == nil {
+			return
+		}
+		defs[name] = rewriteSchemaRefs(ref.Schema)
+		g.walkSchemaRefs(ref.Schema, defs, seen)
+		return
+	}
+	g.walkSchemaRefs(sor.Schema, defs, seen)
+}
+
+// walkSchemaRefs visits every SchemaOrRef reachable from s
+// (properties, items, composition members) and feeds them
+// to collectJSONSchemaDefs.
+func (g *Generator) walkSchemaRefs(s *Schema, defs map[string]*Schema, seen map[string]bool) {
+	if s == nil {
+		return
+	}
+	for _, p := range s.Properties {
+		g.collectJSONSchemaDefs(p, defs, seen)
+	}
+	if s.Items != nil {
+		g.collectJSONSchemaDefs(s.Items, defs, seen)
+	}
+	for _, sub := range s.AllOf {
+		g.collectJSONSchemaDefs(sub, defs, seen)
+	}
+	for _, sub := range s.OneOf {
+		g.collectJSONSchemaDefs(sub, defs, seen)
+	}
+	for _, sub := range s.AnyOf {
+		g.collectJSONSchemaDefs(sub, defs, seen)
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.SchemaOrRef != nil {
+		g.collectJSONSchemaDefs(s.AdditionalProperties.SchemaOrRef, defs, seen)
+	}
+}
+
+// rewriteSchemaRefs returns a shallow copy of s with every
+// nested $ref pointing at #/components/schemas/X rewritten
+// to #/$defs/X, and additionalProperties pinned to false for
+// closed structs (those without a map representation).
+func rewriteSchemaRefs(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	cp := *s
+
+	if len(s.Properties) > 0 {
+		cp.Properties = make(map[string]*SchemaOrRef, len(s.Properties))
+		for k, p := range s.Properties {
+			cp.Properties[k] = rewriteSchemaOrRef(p)
+		}
+	}
+	switch {
+	case s.AdditionalProperties == nil:
+		// A struct-derived schema is always closed, regardless of
+		// whether it ended up with any properties (e.g. every field
+		// was unexported or explicitly ignored), unlike a map-derived
+		// schema, which already carries its own AdditionalProperties.
+		if s.Type == "object" {
+			cp.AdditionalProperties = &AdditionalProperties{Has: boolPtr(false)}
+		}
+	case s.AdditionalProperties.SchemaOrRef != nil:
+		cp.AdditionalProperties = &AdditionalProperties{
+			SchemaOrRef: rewriteSchemaOrRef(s.AdditionalProperties.SchemaOrRef),
+		}
+	}
+	if s.Items != nil {
+		cp.Items = rewriteSchemaOrRef(s.Items)
+	}
+	cp.AllOf = rewriteSchemaOrRefSlice(s.AllOf)
+	cp.OneOf = rewriteSchemaOrRefSlice(s.OneOf)
+	cp.AnyOf = rewriteSchemaOrRefSlice(s.AnyOf)
+
+	return &cp
+}
+
+func rewriteSchemaOrRef(sor *SchemaOrRef) *SchemaOrRef {
+	if sor == nil {
+		return nil
+	}
+	if sor.Ref != "" {
+		return &SchemaOrRef{Ref: jsonSchemaRef(componentsSchemaName(sor.Ref))}
+	}
+	return &SchemaOrRef{Schema: rewriteSchemaRefs(sor.Schema)}
+}
+
+func rewriteSchemaOrRefSlice(in []*SchemaOrRef) []*SchemaOrRef {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*SchemaOrRef, len(in))
+	for i, sor := range in {
+		out[i] = rewriteSchemaOrRef(sor)
+	}
+	return out
+}
+
+// componentsSchemaName extracts X from a ref of the form
+// #/components/schemas/X. It returns an empty string if
+// ref does not match this shape.
+func componentsSchemaName(ref string) string {
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(ref, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(ref, prefix)
+}
+
+// jsonSchemaRef builds a $defs ref from a component name.
+func jsonSchemaRef(name string) string {
+	return "#/$defs/" + name
+}
+
+// jsonSchemaIDBase is the URN namespace EmitJSONSchemas mints
+// a document's $id under. Fizz has no notion of a base URL to
+// publish schemas at, so a URN keeps $id a valid, de-referenceable-
+// in-spirit URI without inventing one.
+const jsonSchemaIDBase = "urn:openapi-schema:"
+
+// jsonSchemaID builds the $id of the standalone document
+// generated for the component named name.
+func jsonSchemaID(name string) string {
+	return jsonSchemaIDBase + name
+}
+
+func boolPtr(b bool) *bool { return &b }
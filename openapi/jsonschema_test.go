@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEmitJSONSchema tests that a standalone JSON Schema
+// document can be generated from a registered Go type and
+// that its internal $refs point to $defs rather than to
+// OpenAPI's #/components/schemas path.
+func TestEmitJSONSchema(t *testing.T) {
+	g := gen(t)
+
+	b, err := g.EmitJSONSchema(rt(new(X)))
+	if err != nil {
+		t.Error(err)
+	}
+	var doc JSONSchema
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, jsonSchemaDraft, doc.DollarSchema)
+	assert.NotEmpty(t, doc.Defs)
+
+	for _, def := range doc.Defs {
+		raw, err := json.Marshal(def)
+		if err != nil {
+			t.Error(err)
+		}
+		assert.NotContains(t, string(raw), "#/components/schemas/")
+	}
+}
+
+// TestEmitJSONSchemaInlineRoot tests that a top-level type
+// that does not itself get a component $ref (e.g. a slice)
+// still has its nested $refs rewritten to $defs, since those
+// refs live directly on the root schema rather than inside
+// an entry of Defs.
+func TestEmitJSONSchemaInlineRoot(t *testing.T) {
+	g := gen(t)
+	g.UseFullSchemaNames(false)
+
+	b, err := g.EmitJSONSchema(rt([]*Y{}))
+	if err != nil {
+		t.Error(err)
+	}
+	var doc JSONSchema
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "array", doc.Type)
+	assert.NotEmpty(t, doc.Defs)
+
+	raw, err := json.Marshal(doc.Schema)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.NotContains(t, string(raw), "#/components/schemas/")
+	assert.Contains(t, string(raw), "#/$defs/")
+}
+
+// TestEmitJSONSchemaUnsupportedType tests that an error is
+// returned when the given type cannot be introspected.
+func TestEmitJSONSchemaUnsupportedType(t *testing.T) {
+	g := gen(t)
+
+	b, err := g.EmitJSONSchema(reflect.TypeOf(func() {}))
+	assert.Nil(t, b)
+	assert.NotNil(t, err)
+}
+
+// TestEmitJSONSchemas tests that every component schema
+// registered on the generator can be emitted as a standalone
+// document keyed by its component name.
+func TestEmitJSONSchemas(t *testing.T) {
+	g := gen(t)
+
+	g.newSchemaFromType(rt(new(X)))
+
+	docs, err := g.EmitJSONSchemas()
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Contains(t, docs, "XXX")
+
+	var doc JSONSchema
+	if err := json.Unmarshal(docs["XXX"], &doc); err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, jsonSchemaID("XXX"), doc.ID)
+}
@@ -0,0 +1,39 @@
+package openapi
+
+// OperationInfo describes additional information about an
+// operation, on top of what's inferred from the input/output
+// types passed to Generator.AddOperation.
+type OperationInfo struct {
+	ID                string
+	StatusCode        int
+	StatusDescription string
+	Headers           []*ResponseHeader
+	Summary           string
+	Description       string
+	Deprecated        bool
+	Responses         []*OperationReponse
+
+	// StreamingResponse, when set, describes the operation as
+	// a streaming endpoint (SSE, NDJSON or WebSocket) instead
+	// of a conventional request/response exchange. See
+	// Generator.buildStreamingResponse in streaming.go.
+	StreamingResponse *StreamingResponse
+}
+
+// ResponseHeader represents the information required to
+// generate a response header.
+type ResponseHeader struct {
+	Name        string
+	Description string
+	Model       interface{}
+}
+
+// OperationReponse represents an additional response
+// that can be returned by an operation, on top of its
+// default, successful one.
+type OperationReponse struct {
+	Code        string
+	Description string
+	Model       interface{}
+	Headers     []*ResponseHeader
+}
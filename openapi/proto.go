@@ -0,0 +1,240 @@
+package openapi
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoScalarTypes maps a protobuf scalar kind to the
+// OpenAPI type/format pair used to represent it, mirroring
+// the conventions of protoc-gen-openapiv2.
+var protoScalarTypes = map[protoreflect.Kind]struct{ typ, format string }{
+	protoreflect.BoolKind:     {"boolean", ""},
+	protoreflect.StringKind:   {"string", ""},
+	protoreflect.BytesKind:    {"string", "byte"},
+	protoreflect.Int32Kind:    {"integer", "int32"},
+	protoreflect.Sint32Kind:   {"integer", "int32"},
+	protoreflect.Sfixed32Kind: {"integer", "int32"},
+	protoreflect.Uint32Kind:   {"integer", "int32"},
+	protoreflect.Fixed32Kind:  {"integer", "int32"},
+	protoreflect.Int64Kind:    {"integer", "int64"},
+	protoreflect.Sint64Kind:   {"integer", "int64"},
+	protoreflect.Sfixed64Kind: {"integer", "int64"},
+	protoreflect.Uint64Kind:   {"integer", "int64"},
+	protoreflect.Fixed64Kind:  {"integer", "int64"},
+	protoreflect.FloatKind:    {"number", "float"},
+	protoreflect.DoubleKind:   {"number", "double"},
+}
+
+// wellKnownProtoSchemas maps the fully qualified name of a
+// handful of well-known protobuf messages to the OpenAPI
+// schema that should represent them, instead of walking
+// their fields.
+var wellKnownProtoSchemas = map[protoreflect.FullName]*Schema{
+	"google.protobuf.Timestamp": {Type: "string", Format: "date-time"},
+	"google.protobuf.Duration":  {Type: "string"},
+	"google.protobuf.Struct":    {Type: "object"},
+	"google.protobuf.Value":     {},
+	"google.protobuf.Empty":     {Type: "object"},
+}
+
+// AddProtoMessage walks a protobuf message descriptor and
+// registers its corresponding component schema, the same
+// way newSchemaFromStruct does for a Go struct. Repeated
+// calls for the same message return the existing reference.
+func (g *Generator) AddProtoMessage(md protoreflect.MessageDescriptor) *SchemaOrRef {
+	name := string(md.Name())
+	if g.UsesFullSchemaNames() {
+		name = protoComponentName(md)
+	}
+	if g.api.Components == nil {
+		g.api.Components = &Components{}
+	}
+	if g.api.Components.Schemas == nil {
+		g.api.Components.Schemas = make(map[string]*SchemaOrRef)
+	}
+	if _, ok := g.api.Components.Schemas[name]; ok {
+		return &SchemaOrRef{Ref: componentsRef(name)}
+	}
+	if known, ok := wellKnownProtoSchemas[md.FullName()]; ok {
+		cp := *known
+		g.api.Components.Schemas[name] = &SchemaOrRef{Schema: &cp}
+		return &SchemaOrRef{Ref: componentsRef(name)}
+	}
+	// Reserve the name before recursing, so that a message
+	// referencing itself (directly or transitively) doesn't
+	// cause infinite recursion.
+	g.api.Components.Schemas[name] = &SchemaOrRef{Schema: &Schema{}}
+
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*SchemaOrRef, md.Fields().Len()),
+	}
+	var oneofGroups []protoreflect.OneofDescriptor
+	oneofMembers := make(map[protoreflect.OneofDescriptor][]string)
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		prop := g.schemaFromProtoField(fd)
+		if prop == nil {
+			continue
+		}
+		jsonName := string(fd.JSONName())
+		schema.Properties[jsonName] = prop
+
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			if _, seen := oneofMembers[oneof]; !seen {
+				oneofGroups = append(oneofGroups, oneof)
+			}
+			oneofMembers[oneof] = append(oneofMembers[oneof], jsonName)
+			continue
+		}
+		// A field has explicit presence when it was declared
+		// with the proto3 "optional" keyword, is a singular
+		// message field or is a proto2 singular field; any
+		// other field is listed as required in the schema.
+		if !fieldHasExplicitPresence(fd) {
+			schema.Required = append(schema.Required, jsonName)
+		}
+	}
+	if desc := protoSourceDescription(md); desc != "" {
+		schema.Description = desc
+	}
+	schema = applyOneofComposition(schema, oneofGroups, oneofMembers)
+	g.api.Components.Schemas[name] = &SchemaOrRef{Schema: schema}
+
+	return &SchemaOrRef{Ref: componentsRef(name)}
+}
+
+// applyOneofComposition folds the real (non-synthetic) oneof
+// groups of a message into its schema as oneOf constraints:
+// each group becomes a oneOf listing, for every member field,
+// an object schema requiring just that one property. When a
+// message declares any oneof, its own object schema is moved
+// under allOf alongside one oneOf entry per group, since a
+// single schema can only carry one oneOf of its own.
+func applyOneofComposition(schema *Schema, groups []protoreflect.OneofDescriptor, members map[protoreflect.OneofDescriptor][]string) *Schema {
+	if len(groups) == 0 {
+		return schema
+	}
+	base := *schema
+	allOf := []*SchemaOrRef{{Schema: &base}}
+
+	for _, group := range groups {
+		names := members[group]
+		choices := make([]*SchemaOrRef, len(names))
+		for i, name := range names {
+			choices[i] = &SchemaOrRef{Schema: &Schema{
+				Type:     "object",
+				Required: []string{name},
+			}}
+		}
+		allOf = append(allOf, &SchemaOrRef{Schema: &Schema{OneOf: choices}})
+	}
+	return &Schema{AllOf: allOf}
+}
+
+// AddOperationFromProto is the protobuf counterpart of
+// AddOperation: it builds request and response schemas from
+// message descriptors instead of reflect.Type, then delegates
+// to AddOperation's lower-level machinery to register the
+// path item.
+func (g *Generator) AddOperationFromProto(path, method, tag string, in, out protoreflect.MessageDescriptor, infos *OperationInfo) error {
+	var inRef, outRef *SchemaOrRef
+	if in != nil {
+		inRef = g.AddProtoMessage(in)
+	}
+	if out != nil {
+		outRef = g.AddProtoMessage(out)
+	}
+	return g.addOperationFromSchemas(path, method, tag, inRef, outRef, infos)
+}
+
+// schemaFromProtoField builds the schema for a single
+// message field, handling repeated fields, maps, enums
+// and nested messages.
+func (g *Generator) schemaFromProtoField(fd protoreflect.FieldDescriptor) *SchemaOrRef {
+	var sor *SchemaOrRef
+
+	switch {
+	case fd.IsMap():
+		valSchema := g.schemaFromProtoField(fd.MapValue())
+		sor = &SchemaOrRef{Schema: &Schema{
+			Type:                 "object",
+			AdditionalProperties: &AdditionalProperties{SchemaOrRef: valSchema},
+		}}
+	case fd.Kind() == protoreflect.EnumKind:
+		sor = schemaFromProtoEnum(fd.Enum())
+	case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+		sor = g.AddProtoMessage(fd.Message())
+	default:
+		t, ok := protoScalarTypes[fd.Kind()]
+		if !ok {
+			g.errors = append(g.errors, fmt.Errorf("openapi: unsupported protobuf kind %s for field %s", fd.Kind(), fd.FullName()))
+			return nil
+		}
+		sor = &SchemaOrRef{Schema: &Schema{Type: t.typ, Format: t.format}}
+	}
+	if fd.IsList() && !fd.IsMap() {
+		sor = &SchemaOrRef{Schema: &Schema{Type: "array", Items: sor}}
+	}
+	if desc := protoSourceDescription(fd); desc != "" && sor.Schema != nil {
+		sor.Schema.Description = desc
+	}
+	// Real (non-synthetic) oneof membership is folded into a
+	// oneOf on the parent message by applyOneofComposition;
+	// the field's own schema is unaffected by it.
+	return sor
+}
+
+// schemaFromProtoEnum builds a string schema whose enum
+// values are the declared enum value names, matching the
+// canonical JSON encoding of protobuf enums.
+func schemaFromProtoEnum(ed protoreflect.EnumDescriptor) *SchemaOrRef {
+	values := ed.Values()
+	enum := make([]interface{}, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		enum[i] = string(values.Get(i).Name())
+	}
+	return &SchemaOrRef{Schema: &Schema{Type: "string", Enum: enum}}
+}
+
+// fieldHasExplicitPresence reports whether fd tracks field
+// presence explicitly, i.e. it is a proto3 "optional" scalar,
+// a singular message field, a oneof member or a proto2
+// singular field, as opposed to a plain proto3 scalar or a
+// repeated field.
+func fieldHasExplicitPresence(fd protoreflect.FieldDescriptor) bool {
+	return fd.HasPresence()
+}
+
+// protoSourceDescription extracts the leading comment
+// attached to d in the originating .proto file, if the
+// descriptor's source locations were compiled in.
+func protoSourceDescription(d protoreflect.Descriptor) string {
+	loc := d.ParentFile().SourceLocations().ByDescriptor(d)
+	return trimProtoComment(loc.LeadingComments)
+}
+
+// trimProtoComment strips the leading spaces and newlines
+// of a SourceCodeInfo comment. Trailing whitespace is kept.
+func trimProtoComment(c string) string {
+	for len(c) > 0 && (c[0] == ' ' || c[0] == '\n') {
+		c = c[1:]
+	}
+	return c
+}
+
+// protoComponentName returns the fully qualified component
+// name used for md when the generator is configured to use
+// full schema names, to avoid collisions between messages
+// of the same short name in different proto packages.
+func protoComponentName(md protoreflect.MessageDescriptor) string {
+	return string(md.FullName())
+}
+
+func componentsRef(name string) string {
+	return "#/components/schemas/" + name
+}
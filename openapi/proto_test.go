@@ -0,0 +1,218 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestAddProtoMessageWellKnown tests that a well-known
+// protobuf message, such as google.protobuf.Timestamp, is
+// mapped to its idiomatic OpenAPI representation instead of
+// being walked field by field.
+func TestAddProtoMessageWellKnown(t *testing.T) {
+	g := gen(t)
+
+	md := (&timestamppb.Timestamp{}).ProtoReflect().Descriptor()
+	sor := g.AddProtoMessage(md)
+	assert.NotNil(t, sor)
+
+	schema := g.resolveSchema(sor)
+	assert.Equal(t, "string", schema.Type)
+	assert.Equal(t, "date-time", schema.Format)
+}
+
+// TestAddProtoMessageIsIdempotent tests that registering the
+// same message descriptor twice returns a reference to the
+// same component instead of generating a duplicate.
+func TestAddProtoMessageIsIdempotent(t *testing.T) {
+	g := gen(t)
+
+	md := (&timestamppb.Timestamp{}).ProtoReflect().Descriptor()
+	first := g.AddProtoMessage(md)
+	second := g.AddProtoMessage(md)
+
+	assert.Equal(t, first.Ref, second.Ref)
+	assert.Len(t, g.api.Components.Schemas, 1)
+}
+
+// TestAddProtoMessageFields tests that scalar, enum, nested
+// message and map fields are all mapped to their expected
+// OpenAPI representation, and that a field declared with the
+// proto3 "optional" keyword is excluded from Required while a
+// plain scalar field is included.
+func TestAddProtoMessageFields(t *testing.T) {
+	g := gen(t)
+	g.UseFullSchemaNames(false)
+
+	widget := widgetDescriptor(t)
+	sor := g.AddProtoMessage(widget)
+	resolved := g.resolveSchema(sor)
+
+	// Widget also declares a real oneof, so its own object
+	// schema is nested as the first allOf member; see
+	// TestAddProtoMessageOneof for that part of the contract.
+	schema := resolved.AllOf[0].Schema
+
+	assert.Equal(t, "object", schema.Type)
+	assert.Contains(t, schema.Properties, "name")
+	assert.Equal(t, "string", schema.Properties["name"].Type)
+	assert.Contains(t, schema.Properties, "count")
+	assert.Equal(t, "integer", schema.Properties["count"].Type)
+
+	status := schema.Properties["status"]
+	assert.Equal(t, "string", status.Type)
+	assert.Equal(t, []interface{}{"UNKNOWN", "ACTIVE"}, status.Enum)
+
+	child := g.resolveSchema(schema.Properties["child"])
+	assert.Equal(t, "object", child.Type)
+	assert.Contains(t, child.Properties, "label")
+
+	// "name" has no explicit presence and must be required;
+	// "nickname" was declared optional and must not be.
+	assert.Contains(t, schema.Required, "name")
+	assert.NotContains(t, schema.Required, "nickname")
+}
+
+// TestAddProtoMessageOneof tests that a real proto3 oneof is
+// folded into a oneOf constraint listing one single-property
+// object schema per member, nested under allOf alongside the
+// message's own object schema.
+func TestAddProtoMessageOneof(t *testing.T) {
+	g := gen(t)
+	g.UseFullSchemaNames(false)
+
+	widget := widgetDescriptor(t)
+	g.AddProtoMessage(widget)
+
+	schema := g.api.Components.Schemas["Widget"].Schema
+	if assert.Len(t, schema.AllOf, 2) {
+		base := schema.AllOf[0].Schema
+		assert.Contains(t, base.Properties, "email")
+		assert.Contains(t, base.Properties, "phone")
+
+		oneOf := schema.AllOf[1].Schema
+		assert.Len(t, oneOf.OneOf, 2)
+	}
+}
+
+// widgetDescriptor builds, at runtime and without relying on
+// generated code, a message descriptor exercising a scalar
+// field, a proto3 optional scalar, an enum field, a nested
+// message field and a real (non-synthetic) oneof.
+func widgetDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	proto3Optional := true
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    strPtr("widget.proto"),
+		Package: strPtr("testpb"),
+		Syntax:  strPtr("proto3"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			{
+				Name: strPtr("Status"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: strPtr("UNKNOWN"), Number: int32Ptr(0)},
+					{Name: strPtr("ACTIVE"), Number: int32Ptr(1)},
+				},
+			},
+		},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: strPtr("Nested"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     strPtr("label"),
+						Number:   int32Ptr(1),
+						Label:    labelPtr(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+						Type:     typePtr(descriptorpb.FieldDescriptorProto_TYPE_STRING),
+						JsonName: strPtr("label"),
+					},
+				},
+			},
+			{
+				Name: strPtr("Widget"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     strPtr("name"),
+						Number:   int32Ptr(1),
+						Label:    labelPtr(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+						Type:     typePtr(descriptorpb.FieldDescriptorProto_TYPE_STRING),
+						JsonName: strPtr("name"),
+					},
+					{
+						Name:     strPtr("count"),
+						Number:   int32Ptr(2),
+						Label:    labelPtr(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+						Type:     typePtr(descriptorpb.FieldDescriptorProto_TYPE_INT32),
+						JsonName: strPtr("count"),
+					},
+					{
+						Name:           strPtr("nickname"),
+						Number:         int32Ptr(3),
+						Label:          labelPtr(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+						Type:           typePtr(descriptorpb.FieldDescriptorProto_TYPE_STRING),
+						JsonName:       strPtr("nickname"),
+						Proto3Optional: &proto3Optional,
+						OneofIndex:     int32Ptr(1),
+					},
+					{
+						Name:     strPtr("status"),
+						Number:   int32Ptr(4),
+						Label:    labelPtr(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+						Type:     typePtr(descriptorpb.FieldDescriptorProto_TYPE_ENUM),
+						TypeName: strPtr(".testpb.Status"),
+						JsonName: strPtr("status"),
+					},
+					{
+						Name:     strPtr("child"),
+						Number:   int32Ptr(5),
+						Label:    labelPtr(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+						Type:     typePtr(descriptorpb.FieldDescriptorProto_TYPE_MESSAGE),
+						TypeName: strPtr(".testpb.Nested"),
+						JsonName: strPtr("child"),
+					},
+					{
+						Name:       strPtr("email"),
+						Number:     int32Ptr(6),
+						Label:      labelPtr(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+						Type:       typePtr(descriptorpb.FieldDescriptorProto_TYPE_STRING),
+						JsonName:   strPtr("email"),
+						OneofIndex: int32Ptr(0),
+					},
+					{
+						Name:       strPtr("phone"),
+						Number:     int32Ptr(7),
+						Label:      labelPtr(descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+						Type:       typePtr(descriptorpb.FieldDescriptorProto_TYPE_STRING),
+						JsonName:   strPtr("phone"),
+						OneofIndex: int32Ptr(0),
+					},
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{
+					{Name: strPtr("contact")},
+					{Name: strPtr("_nickname")},
+				},
+			},
+		},
+	}
+	file, err := protodesc.NewFile(fd, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file.Messages().ByName("Widget")
+}
+
+func strPtr(s string) *string { return &s }
+func int32Ptr(i int32) *int32 { return &i }
+func labelPtr(l descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto_Label {
+	return &l
+}
+func typePtr(ty descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto_Type {
+	return &ty
+}
@@ -0,0 +1,345 @@
+package openapi
+
+import "encoding/json"
+
+// OpenAPI represents the root document object of
+// an OpenAPI document.
+type OpenAPI struct {
+	OpenAPI    string      `json:"openapi"`
+	Info       *Info       `json:"info"`
+	Servers    []*Server   `json:"servers,omitempty"`
+	Paths      Paths       `json:"paths"`
+	Components *Components `json:"components,omitempty"`
+	Tags       []*Tag      `json:"tags,omitempty"`
+}
+
+// Components holds a set of reusable objects for different
+// aspects of the specification.
+type Components struct {
+	Schemas    map[string]*SchemaOrRef    `json:"schemas,omitempty"`
+	Responses  map[string]*ResponseOrRef  `json:"responses,omitempty"`
+	Parameters map[string]*ParameterOrRef `json:"parameters,omitempty"`
+	Headers    map[string]*HeaderOrRef    `json:"headers,omitempty"`
+}
+
+// Info represents the metadata of an API.
+type Info struct {
+	Title          string   `json:"title"`
+	Description    string   `json:"description,omitempty"`
+	TermsOfService string   `json:"termsOfService,omitempty"`
+	Contact        *Contact `json:"contact,omitempty"`
+	License        *License `json:"license,omitempty"`
+	Version        string   `json:"version"`
+}
+
+// Contact represents the contact information exposed for an API.
+type Contact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// License represents the license information exposed for an API.
+type License struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Server represents a server.
+type Server struct {
+	URL         string                     `json:"url"`
+	Description string                     `json:"description,omitempty"`
+	Variables   map[string]*ServerVariable `json:"variables,omitempty"`
+}
+
+// ServerVariable represents a server variable for server
+// URL template substitution.
+type ServerVariable struct {
+	Enum        []string `json:"enum,omitempty"`
+	Default     string   `json:"default"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Paths represents the relative paths to the individual
+// endpoints and their operations.
+type Paths map[string]*PathItem
+
+// PathItem describes the operations available on a single
+// API path.
+type PathItem struct {
+	Summary     string            `json:"summary,omitempty"`
+	Description string            `json:"description,omitempty"`
+	GET         *Operation        `json:"get,omitempty"`
+	PUT         *Operation        `json:"put,omitempty"`
+	POST        *Operation        `json:"post,omitempty"`
+	DELETE      *Operation        `json:"delete,omitempty"`
+	OPTIONS     *Operation        `json:"options,omitempty"`
+	HEAD        *Operation        `json:"head,omitempty"`
+	PATCH       *Operation        `json:"patch,omitempty"`
+	TRACE       *Operation        `json:"trace,omitempty"`
+	Parameters  []*ParameterOrRef `json:"parameters,omitempty"`
+}
+
+// Parameter describes a single operation parameter.
+type Parameter struct {
+	Name            string       `json:"name"`
+	In              string       `json:"in"`
+	Description     string       `json:"description,omitempty"`
+	Required        bool         `json:"required,omitempty"`
+	Deprecated      bool         `json:"deprecated,omitempty"`
+	AllowEmptyValue bool         `json:"allowEmptyValue,omitempty"`
+	Schema          *SchemaOrRef `json:"schema,omitempty"`
+	Style           string       `json:"style,omitempty"`
+	Explode         bool         `json:"explode,omitempty"`
+}
+
+// ParameterOrRef represents a Parameter that can be inlined
+// or referenced in the API description.
+type ParameterOrRef struct {
+	*Parameter
+	Ref string `json:"$ref,omitempty"`
+}
+
+// RequestBody represents a request body.
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+	Required    bool                  `json:"required,omitempty"`
+}
+
+// SchemaOrRef represents a Schema that can be inlined or
+// referenced, either relatively to the OpenAPI document's
+// own components (#/components/schemas/X) or, once rewritten
+// by EmitJSONSchema(s), to a standalone document's $defs
+// (#/$defs/X).
+type SchemaOrRef struct {
+	*Schema
+	Ref string `json:"$ref,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler for SchemaOrRef. It
+// marshals to a bare $ref object when Ref is set, instead of
+// relying on the promoted Schema.MarshalJSON, which would be
+// invoked on a nil Schema and panic whenever a reference isn't
+// paired with an inlined schema.
+func (s *SchemaOrRef) MarshalJSON() ([]byte, error) {
+	if s.Ref != "" {
+		return json.Marshal(struct {
+			Ref string `json:"$ref"`
+		}{s.Ref})
+	}
+	return json.Marshal(s.Schema)
+}
+
+// AdditionalProperties represents the additionalProperties
+// keyword of a schema, which in both JSON Schema and OpenAPI
+// is either a boolean or a schema.
+type AdditionalProperties struct {
+	*SchemaOrRef
+	Has *bool
+}
+
+// MarshalJSON implements json.Marshaler for AdditionalProperties.
+func (ap *AdditionalProperties) MarshalJSON() ([]byte, error) {
+	if ap.Has != nil {
+		return json.Marshal(*ap.Has)
+	}
+	return json.Marshal(ap.SchemaOrRef)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for AdditionalProperties.
+func (ap *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "true":
+		b := true
+		ap.Has = &b
+		return nil
+	case "false":
+		b := false
+		ap.Has = &b
+		return nil
+	}
+	var sor SchemaOrRef
+	if err := json.Unmarshal(data, &sor); err != nil {
+		return err
+	}
+	ap.SchemaOrRef = &sor
+	return nil
+}
+
+// Discriminator aids OpenAPI consumers in disambiguating
+// between the possible schemas of a oneOf/anyOf composition.
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+// Schema represents the definition of input and output data
+// types of the API.
+type Schema struct {
+	// The following properties are taken from the JSON Schema
+	// definition but their definitions were adjusted to the
+	// OpenAPI Specification.
+	Type                 string                  `json:"type,omitempty"`
+	AllOf                []*SchemaOrRef          `json:"allOf,omitempty"`
+	OneOf                []*SchemaOrRef          `json:"oneOf,omitempty"`
+	AnyOf                []*SchemaOrRef          `json:"anyOf,omitempty"`
+	Items                *SchemaOrRef            `json:"items,omitempty"`
+	Properties           map[string]*SchemaOrRef `json:"properties,omitempty"`
+	AdditionalProperties *AdditionalProperties   `json:"additionalProperties,omitempty"`
+	Discriminator        *Discriminator          `json:"discriminator,omitempty"`
+	Description          string                  `json:"description,omitempty"`
+	Format               string                  `json:"format,omitempty"`
+	Default              interface{}             `json:"default,omitempty"`
+
+	// The following properties are taken directly from the
+	// JSON Schema definition and follow the same specifications.
+	Title            string        `json:"title,omitempty"`
+	MultipleOf       int           `json:"multipleOf,omitempty"`
+	Maximum          int           `json:"maximum,omitempty"`
+	ExclusiveMaximum bool          `json:"exclusiveMaximum,omitempty"`
+	Minimum          int           `json:"minimum,omitempty"`
+	ExclusiveMinimum bool          `json:"exclusiveMinimum,omitempty"`
+	MaxLength        int           `json:"maxLength,omitempty"`
+	MinLength        int           `json:"minLength,omitempty"`
+	Pattern          string        `json:"pattern,omitempty"`
+	MaxItems         int           `json:"maxItems,omitempty"`
+	MinItems         int           `json:"minItems,omitempty"`
+	UniqueItems      bool          `json:"uniqueItems,omitempty"`
+	MaxProperties    int           `json:"maxProperties,omitempty"`
+	MinProperties    int           `json:"minProperties,omitempty"`
+	Required         []string      `json:"required,omitempty"`
+	Enum             []interface{} `json:"enum,omitempty"`
+	Nullable         bool          `json:"nullable,omitempty"`
+	Deprecated       bool          `json:"deprecated,omitempty"`
+
+	// Ref is only ever populated on a Schema (as opposed to on
+	// its enclosing SchemaOrRef) by EmitJSONSchema(s), whose
+	// JSON Schema output has no OpenAPI-style split ref wrapper.
+	Ref string `json:"$ref,omitempty"`
+
+	// Extensions holds the vendor extension (x-*) entries
+	// attached to this schema, e.g. through a `openapi:"x-*"`
+	// struct tag or a SchemaHook/FieldHook. See hooks.go.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler for Schema, inlining
+// Extensions alongside the schema's own fields.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type alias Schema
+	return marshalWithExtensions((*alias)(s), s.Extensions)
+}
+
+// Operation describes an API operation on a path.
+type Operation struct {
+	Tags        []string               `json:"tags,omitempty"`
+	Summary     string                 `json:"summary,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	ID          string                 `json:"operationId,omitempty"`
+	Parameters  []*ParameterOrRef      `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"requestBody,omitempty"`
+	Responses   Responses              `json:"responses,omitempty"`
+	Deprecated  bool                   `json:"deprecated,omitempty"`
+	Extensions  map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler for Operation, inlining
+// Extensions (e.g. x-websocket) alongside the operation's own fields.
+func (op *Operation) MarshalJSON() ([]byte, error) {
+	type alias Operation
+	return marshalWithExtensions((*alias)(op), op.Extensions)
+}
+
+// Responses represents a container for the expected responses
+// of an operation. It maps a HTTP response code to the expected
+// response.
+type Responses map[string]*ResponseOrRef
+
+// ResponseOrRef represents a Response that can be inlined
+// or referenced in the API description.
+type ResponseOrRef struct {
+	*Response
+	Ref string `json:"$ref,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler for ResponseOrRef, for
+// the same reason as SchemaOrRef.MarshalJSON: the promoted
+// Response.MarshalJSON would panic on a nil Response.
+func (r *ResponseOrRef) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return json.Marshal(struct {
+			Ref string `json:"$ref"`
+		}{r.Ref})
+	}
+	return json.Marshal(r.Response)
+}
+
+// Response describes a single response from an API.
+type Response struct {
+	Description string                  `json:"description,omitempty"`
+	Headers     map[string]*HeaderOrRef `json:"headers,omitempty"`
+	Content     map[string]*MediaType   `json:"content,omitempty"`
+	Extensions  map[string]interface{}  `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler for Response, inlining
+// Extensions (e.g. x-stream-events) alongside its own fields.
+func (r *Response) MarshalJSON() ([]byte, error) {
+	type alias Response
+	return marshalWithExtensions((*alias)(r), r.Extensions)
+}
+
+// HeaderOrRef represents a Header that can be inlined
+// or referenced in the API description.
+type HeaderOrRef struct {
+	*Header
+	Ref string `json:"$ref,omitempty"`
+}
+
+// Header represents an HTTP header.
+type Header struct {
+	Description     string       `json:"description,omitempty"`
+	Required        bool         `json:"required,omitempty"`
+	Deprecated      bool         `json:"deprecated,omitempty"`
+	AllowEmptyValue bool         `json:"allowEmptyValue,omitempty"`
+	Schema          *SchemaOrRef `json:"schema,omitempty"`
+}
+
+// MediaType represents the type of a media.
+type MediaType struct {
+	Schema  *SchemaOrRef `json:"schema"`
+	Example interface{}  `json:"example,omitempty"`
+}
+
+// Tag represents the metadata of a single tag.
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// marshalWithExtensions marshals base, then merges ext into
+// the resulting JSON object, so vendor extension keys (x-*)
+// end up inlined alongside the type's own fields instead of
+// nested under a dedicated property.
+func marshalWithExtensions(base interface{}, ext map[string]interface{}) ([]byte, error) {
+	b, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	if len(ext) == 0 {
+		return b, nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	for k, v := range ext {
+		eb, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		m[k] = eb
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,169 @@
+package openapi
+
+import "reflect"
+
+// StreamKind identifies the transport used by a streaming
+// operation response.
+type StreamKind string
+
+const (
+	// StreamSSE describes a server-sent events response,
+	// emitted as a text/event-stream content type.
+	StreamSSE StreamKind = "sse"
+	// StreamNDJSON describes a newline-delimited JSON
+	// response, emitted as application/x-ndjson.
+	StreamNDJSON StreamKind = "ndjson"
+	// StreamWebSocket describes a bidirectional WebSocket
+	// exchange, modeled through the x-websocket extension
+	// rather than as a regular response content type.
+	StreamWebSocket StreamKind = "websocket"
+)
+
+// streamContentTypes maps a StreamKind to the media type
+// used for its response content, for the kinds that are
+// representable as a regular (unidirectional) response body.
+var streamContentTypes = map[StreamKind]string{
+	StreamSSE:    "text/event-stream",
+	StreamNDJSON: "application/x-ndjson",
+}
+
+// StreamEvent describes one named event that may appear in a
+// streaming response, along with the Go type of its payload.
+type StreamEvent struct {
+	// Name is the event name. For SSE, this is the value of
+	// the "event:" field; it is omitted from NDJSON streams,
+	// which carry a single implicit event type.
+	Name string
+	// Model is the Go value (or type) whose schema describes
+	// the event's payload, following the same conventions as
+	// OperationReponse.Model.
+	Model interface{}
+	// Description documents the event for API consumers.
+	Description string
+}
+
+// StreamingResponse describes a non-JSON-request/response
+// operation: a server-sent events or NDJSON stream, or a
+// WebSocket exchange.
+type StreamingResponse struct {
+	// Kind selects the transport.
+	Kind StreamKind
+	// Events lists every event kind the stream may emit. For
+	// a WebSocket operation, these are the messages sent from
+	// server to client; see Inbound for the other direction.
+	Events []*StreamEvent
+	// Inbound lists the message kinds a WebSocket client may
+	// send to the server. It is only meaningful when Kind is
+	// StreamWebSocket.
+	Inbound []*StreamEvent
+	// Discriminator names the JSON field used to tell events
+	// apart when they share a single schema, e.g. a "type"
+	// field on an envelope.
+	Discriminator string
+}
+
+// buildStreamingResponse turns infos.StreamingResponse into
+// the Response object registered for the operation, routing
+// SSE and NDJSON through the regular content-type table and
+// WebSocket through the x-websocket vendor extension.
+func (g *Generator) buildStreamingResponse(op *Operation, infos *OperationInfo) error {
+	sr := infos.StreamingResponse
+	if sr == nil {
+		return nil
+	}
+	if sr.Kind == StreamWebSocket {
+		ext, err := g.websocketExtension(sr)
+		if err != nil {
+			return err
+		}
+		if op.Extensions == nil {
+			op.Extensions = make(map[string]interface{})
+		}
+		op.Extensions["x-websocket"] = ext
+		return nil
+	}
+	contentType, ok := streamContentTypes[sr.Kind]
+	if !ok {
+		return newUnsupportedStreamKindError(sr.Kind)
+	}
+	events := make(map[string]interface{}, len(sr.Events))
+	for _, ev := range sr.Events {
+		sor, err := g.schemaOrRefFromModel(ev.Model)
+		if err != nil {
+			return err
+		}
+		events[ev.Name] = map[string]interface{}{
+			"schema":      sor,
+			"description": ev.Description,
+		}
+	}
+	code := defaultStatusCode(infos)
+	resp := op.Responses[code]
+	if resp == nil || resp.Response == nil {
+		resp = &ResponseOrRef{Response: &Response{}}
+		op.Responses[code] = resp
+	}
+	if resp.Response.Content == nil {
+		resp.Response.Content = make(map[string]*MediaType)
+	}
+	resp.Response.Content[contentType] = &MediaType{}
+	if resp.Response.Extensions == nil {
+		resp.Response.Extensions = make(map[string]interface{})
+	}
+	resp.Response.Extensions["x-stream-events"] = events
+
+	return nil
+}
+
+// websocketExtension builds the x-websocket vendor extension
+// payload describing the inbound and outbound message
+// schemas of a WebSocket operation, keyed by discriminator
+// value when one is set.
+func (g *Generator) websocketExtension(sr *StreamingResponse) (map[string]interface{}, error) {
+	outbound, err := g.schemasByEventName(sr.Events)
+	if err != nil {
+		return nil, err
+	}
+	inbound, err := g.schemasByEventName(sr.Inbound)
+	if err != nil {
+		return nil, err
+	}
+	ext := map[string]interface{}{
+		"outbound": outbound,
+		"inbound":  inbound,
+	}
+	if sr.Discriminator != "" {
+		ext["discriminator"] = sr.Discriminator
+	}
+	return ext, nil
+}
+
+func (g *Generator) schemasByEventName(events []*StreamEvent) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(events))
+	for _, ev := range events {
+		sor, err := g.schemaOrRefFromModel(ev.Model)
+		if err != nil {
+			return nil, err
+		}
+		out[ev.Name] = map[string]interface{}{
+			"schema":      sor,
+			"description": ev.Description,
+		}
+	}
+	return out, nil
+}
+
+// schemaOrRefFromModel resolves the schema of a model value
+// the same way setOperationResponse does for a regular
+// response body.
+func (g *Generator) schemaOrRefFromModel(model interface{}) (*SchemaOrRef, error) {
+	if model == nil {
+		return nil, nil
+	}
+	t := reflect.TypeOf(model)
+	sor := g.newSchemaFromType(t)
+	if sor == nil {
+		return nil, newUnsupportedModelError(t)
+	}
+	return sor, nil
+}
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuildStreamingResponseSSE tests that an SSE streaming
+// response is emitted as a text/event-stream content type
+// carrying an x-stream-events extension listing each event's
+// schema.
+func TestBuildStreamingResponseSSE(t *testing.T) {
+	type Tick struct {
+		N int `json:"n"`
+	}
+	g := gen(t)
+
+	op := &Operation{Responses: make(Responses)}
+	infos := &OperationInfo{
+		StreamingResponse: &StreamingResponse{
+			Kind: StreamSSE,
+			Events: []*StreamEvent{
+				{Name: "tick", Model: Tick{}, Description: "A periodic tick"},
+			},
+		},
+	}
+	err := g.buildStreamingResponse(op, infos)
+	if err != nil {
+		t.Error(err)
+	}
+	resp := op.Responses["200"]
+	assert.NotNil(t, resp)
+	assert.Contains(t, resp.Response.Content, "text/event-stream")
+	assert.Contains(t, resp.Response.Extensions, "x-stream-events")
+}
+
+// TestBuildStreamingResponseWebSocket tests that a WebSocket
+// operation is described through the x-websocket extension
+// on the operation rather than through a response content
+// type.
+func TestBuildStreamingResponseWebSocket(t *testing.T) {
+	type ClientMsg struct {
+		Cmd string `json:"cmd"`
+	}
+	type ServerMsg struct {
+		Ack bool `json:"ack"`
+	}
+	g := gen(t)
+
+	op := &Operation{Responses: make(Responses)}
+	infos := &OperationInfo{
+		StreamingResponse: &StreamingResponse{
+			Kind:    StreamWebSocket,
+			Events:  []*StreamEvent{{Name: "ack", Model: ServerMsg{}}},
+			Inbound: []*StreamEvent{{Name: "cmd", Model: ClientMsg{}}},
+		},
+	}
+	err := g.buildStreamingResponse(op, infos)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Empty(t, op.Responses)
+	assert.Contains(t, op.Extensions, "x-websocket")
+}
+
+// TestRegisterProducer tests that a content type registered
+// for a specific operation is added on top of the default
+// producers, that a content type registered with an empty
+// opID is added for every operation, and that registering the
+// same content type twice doesn't duplicate it.
+func TestRegisterProducer(t *testing.T) {
+	g := gen(t)
+
+	g.RegisterProducer("ExportCSV", "text/csv")
+	g.RegisterProducer("", "application/cbor")
+	g.RegisterProducer("", "application/json") // already a default, must not duplicate
+
+	assert.Equal(t, []string{"application/json", "application/cbor", "text/csv"}, g.producersFor("ExportCSV"))
+	assert.Equal(t, []string{"application/json", "application/cbor"}, g.producersFor("OtherOp"))
+}
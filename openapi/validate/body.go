@@ -0,0 +1,145 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/wI2L/fizz/openapi"
+)
+
+// validateRequestBody validates r's JSON body, if any,
+// against the schema declared on the operation's request
+// body for the "application/json" content type.
+func (v *Validator) validateRequestBody(op *openapi.Operation, r *http.Request) error {
+	schema := requestBodySchema(op, "application/json")
+	if schema == nil {
+		return nil
+	}
+	b, err := readBody(r)
+	if err != nil {
+		return &ValidationError{Errors: []FieldError{{Location: "body", Message: err.Error()}}}
+	}
+	if len(b) == 0 {
+		if op.RequestBody != nil && op.RequestBody.Required {
+			return &ValidationError{Errors: []FieldError{{Location: "body", Message: "request body is required"}}}
+		}
+		return nil
+	}
+	var payload interface{}
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return &ValidationError{Errors: []FieldError{{Location: "body", Message: "invalid JSON: " + err.Error()}}}
+	}
+	verr := &ValidationError{}
+	validateAgainstSchema(verr, "body", "", schema, payload)
+	if len(verr.Errors) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// validateResponseBody validates the body recorded by rec
+// against the schema and status code declared for the
+// matched operation's response.
+func (v *Validator) validateResponseBody(op *openapi.Operation, rec *responseRecorder) error {
+	resp, ok := op.Responses[strconv.Itoa(rec.status)]
+	if !ok {
+		return fmt.Errorf("unexpected status code %d", rec.status)
+	}
+	media, ok := resp.Content["application/json"]
+	if !ok || media.Schema == nil {
+		return nil
+	}
+	if rec.body.Len() == 0 {
+		return nil
+	}
+	var payload interface{}
+	if err := json.Unmarshal(rec.body.Bytes(), &payload); err != nil {
+		return fmt.Errorf("invalid JSON response body: %w", err)
+	}
+	verr := &ValidationError{}
+	validateAgainstSchema(verr, "body", "", media.Schema, payload)
+	if len(verr.Errors) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// requestBodySchema returns the JSON schema declared for
+// the operation's request body, or nil if none was set for
+// the given content type.
+func requestBodySchema(op *openapi.Operation, contentType string) *openapi.SchemaOrRef {
+	if op.RequestBody == nil {
+		return nil
+	}
+	media, ok := op.RequestBody.Content[contentType]
+	if !ok {
+		return nil
+	}
+	return media.Schema
+}
+
+// validateAgainstSchema is a small, non-exhaustive JSON
+// Schema checker covering the subset of keywords Fizz
+// itself emits: type, required, properties and items. It does
+// not attempt to validate formats, numeric ranges or string
+// patterns; those are left to the handler's own tonic/
+// validator-backed binding, which still runs afterwards. A
+// schema given only as a $ref is not resolved: the value it
+// describes is accepted without any check.
+func validateAgainstSchema(verr *ValidationError, loc, field string, sor *openapi.SchemaOrRef, value interface{}) {
+	if sor == nil || sor.Schema == nil {
+		return
+	}
+	s := sor.Schema
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			verr.add(loc, field, "expected an object")
+			return
+		}
+		for _, req := range s.Required {
+			if _, ok := obj[req]; !ok {
+				verr.add(loc, joinField(field, req), "required property is missing")
+			}
+		}
+		for name, val := range obj {
+			prop, ok := s.Properties[name]
+			if !ok {
+				continue
+			}
+			validateAgainstSchema(verr, loc, joinField(field, name), prop, val)
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			verr.add(loc, field, "expected an array")
+			return
+		}
+		for i, item := range arr {
+			validateAgainstSchema(verr, loc, fmt.Sprintf("%s[%d]", field, i), s.Items, item)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			verr.add(loc, field, "expected a string")
+		}
+	case "integer", "number":
+		if _, ok := value.(float64); !ok {
+			verr.add(loc, field, "expected a number")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			verr.add(loc, field, "expected a boolean")
+		}
+	}
+}
+
+func joinField(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "." + child
+}
@@ -0,0 +1,112 @@
+package validate
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/wI2L/fizz/openapi"
+)
+
+// validateParams validates the path, query and header
+// parameters of r against the operation's declared
+// Parameters, coercing them to their declared type, applying
+// defaults, and rejecting query parameters that are not
+// declared on the operation.
+func (v *Validator) validateParams(op *openapi.Operation, r *http.Request, pathParams map[string]string) error {
+	verr := &ValidationError{}
+
+	query := r.URL.Query()
+	declaredQuery := make(map[string]bool)
+
+	for _, p := range op.Parameters {
+		if p == nil || p.Parameter == nil {
+			continue
+		}
+		switch p.In {
+		case "path":
+			validateParamValue(verr, "path", p.Parameter, pathParams[p.Name], true)
+
+		case "query":
+			declaredQuery[p.Name] = true
+			if !query.Has(p.Name) && p.Schema != nil && p.Schema.Default != nil {
+				query.Set(p.Name, toQueryString(p.Schema.Default))
+				r.URL.RawQuery = query.Encode()
+			}
+			present := query.Has(p.Name)
+			validateParamValue(verr, "query", p.Parameter, query.Get(p.Name), present)
+
+		case "header":
+			val := r.Header.Get(p.Name)
+			validateParamValue(verr, "header", p.Parameter, val, val != "")
+		}
+	}
+	for name := range query {
+		if !declaredQuery[name] {
+			verr.add("query", name, "unknown query parameter")
+		}
+	}
+	if len(verr.Errors) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// validateParamValue checks a single parameter's presence,
+// enum membership and basic type against its schema.
+func validateParamValue(verr *ValidationError, loc string, p *openapi.Parameter, value string, present bool) {
+	if !present {
+		if p.Required {
+			verr.add(loc, p.Name, "required parameter is missing")
+		}
+		return
+	}
+	if p.Schema == nil || p.Schema.Schema == nil {
+		return
+	}
+	if err := checkScalarType(p.Schema.Type, value); err != nil {
+		verr.add(loc, p.Name, "%s", err)
+		return
+	}
+	if len(p.Schema.Enum) > 0 && !enumContains(p.Schema.Enum, value) {
+		verr.add(loc, p.Name, "value %q is not one of the allowed enum values", value)
+	}
+}
+
+// checkScalarType reports whether value can be parsed as
+// the given OpenAPI scalar type. Non-scalar types (object,
+// array) are not checked here, since parameters only ever
+// carry scalars or comma-separated arrays of scalars.
+func checkScalarType(typ, value string) error {
+	switch typ {
+	case "integer":
+		if _, err := parseInt(value); err != nil {
+			return err
+		}
+	case "boolean":
+		if _, err := parseBool(value); err != nil {
+			return err
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func enumContains(enum []interface{}, value string) bool {
+	for _, e := range enum {
+		if toQueryString(e) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func toQueryString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,270 @@
+// Package validate turns the OpenAPI document assembled by a
+// openapi.Generator into a runtime contract: an http.Handler
+// middleware that rejects requests and, optionally, responses
+// that do not conform to the spec.
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wI2L/fizz/openapi"
+)
+
+// Validator validates inbound requests, and optionally
+// outbound responses, against an OpenAPI document.
+type Validator struct {
+	ops              []*matchedOperation
+	validateResponse bool
+}
+
+// Option configures a Validator.
+type Option func(*Validator)
+
+// WithResponseValidation enables validation of response
+// bodies and status codes against the matched operation.
+// It is disabled by default, since it requires buffering
+// the whole response body in memory.
+func WithResponseValidation() Option {
+	return func(v *Validator) { v.validateResponse = true }
+}
+
+// matchedOperation pairs a compiled path matcher with the
+// OpenAPI operation it was built from, for every method
+// registered on that path.
+type matchedOperation struct {
+	pattern  *regexp.Regexp
+	names    []string
+	byMethod map[string]*openapi.Operation
+}
+
+// New builds a Validator from the OpenAPI document produced
+// by a openapi.Generator's API method.
+func New(api *openapi.OpenAPI, opts ...Option) (*Validator, error) {
+	if api == nil {
+		return nil, fmt.Errorf("validate: nil OpenAPI document")
+	}
+	v := &Validator{}
+	for _, opt := range opts {
+		opt(v)
+	}
+	for path, item := range api.Paths {
+		pattern, names := compilePath(path)
+		mo := &matchedOperation{
+			pattern: pattern,
+			names:   names,
+			byMethod: map[string]*openapi.Operation{
+				http.MethodGet:     item.GET,
+				http.MethodPost:    item.POST,
+				http.MethodPut:     item.PUT,
+				http.MethodPatch:   item.PATCH,
+				http.MethodDelete:  item.DELETE,
+				http.MethodHead:    item.HEAD,
+				http.MethodOptions: item.OPTIONS,
+				http.MethodTrace:   item.TRACE,
+			},
+		}
+		v.ops = append(v.ops, mo)
+	}
+	return v, nil
+}
+
+// Middleware wraps next with request (and, if enabled,
+// response) validation against the OpenAPI document.
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, params := v.match(r)
+		if op == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := v.validateParams(op, r, params); err != nil {
+			writeValidationError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := v.validateRequestBody(op, r); err != nil {
+			writeValidationError(w, http.StatusBadRequest, err)
+			return
+		}
+		if !v.validateResponse {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rec := newResponseRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		if err := v.validateResponseBody(op, rec); err != nil {
+			// The response is still buffered at this point, so the
+			// violation header reaches the client alongside
+			// whatever the handler wrote, instead of arriving too
+			// late to be part of the flushed header set.
+			rec.Header().Set("X-OpenAPI-Validation-Error", err.Error())
+		}
+		rec.flush()
+	})
+}
+
+// match finds the operation whose path template and method
+// match r, and returns the path parameters extracted from
+// the request's URL.
+func (v *Validator) match(r *http.Request) (*openapi.Operation, map[string]string) {
+	for _, mo := range v.ops {
+		m := mo.pattern.FindStringSubmatch(r.URL.Path)
+		if m == nil {
+			continue
+		}
+		op := mo.byMethod[r.Method]
+		if op == nil {
+			continue
+		}
+		params := make(map[string]string, len(mo.names))
+		for i, name := range mo.names {
+			params[name] = m[i+1]
+		}
+		return op, params
+	}
+	return nil, nil
+}
+
+// segmentPattern matches a single {name} placeholder in an
+// OpenAPI path template.
+var segmentPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// compilePath turns an OpenAPI path template such as
+// /pets/{id} into a matching regexp and the ordered list
+// of parameter names found in it. Only the literal runs of
+// the template are escaped with QuoteMeta; the {name}
+// placeholders themselves are substituted after escaping, so
+// their braces never reach QuoteMeta.
+func compilePath(path string) (*regexp.Regexp, []string) {
+	var (
+		names []string
+		b     strings.Builder
+		last  int
+	)
+	for _, loc := range segmentPattern.FindAllStringSubmatchIndex(path, -1) {
+		b.WriteString(regexp.QuoteMeta(path[last:loc[0]]))
+		names = append(names, path[loc[2]:loc[3]])
+		b.WriteString(`([^/]+)`)
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(path[last:]))
+
+	return regexp.MustCompile("^" + b.String() + "$"), names
+}
+
+// FieldError describes a single parameter or body field
+// that failed validation.
+type FieldError struct {
+	Location string `json:"in"` // path, query, header or body.
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %q: %s", e.Location, e.Field, e.Message)
+}
+
+// ValidationError collects every FieldError produced while
+// validating a single request or response.
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) add(loc, field, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, FieldError{Location: loc, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func writeValidationError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(err)
+}
+
+// responseRecorder fully buffers a handler's response -
+// headers, status and body - instead of passing it through
+// to the real ResponseWriter as it is written. This lets the
+// validation error header be added (or the whole response
+// rejected, in the future) before anything is actually sent,
+// since a real http.ResponseWriter commits its header as soon
+// as WriteHeader or the first Write call occurs.
+type responseRecorder struct {
+	http.ResponseWriter
+	header      http.Header
+	status      int
+	body        *bytes.Buffer
+	wroteHeader bool
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{
+		ResponseWriter: w,
+		header:         make(http.Header),
+		status:         http.StatusOK,
+		body:           &bytes.Buffer{},
+	}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.status = status
+	r.wroteHeader = true
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	return r.body.Write(b)
+}
+
+// flush copies the buffered header, status code and body to
+// the real ResponseWriter, in the order a handler writing to
+// it directly would have.
+func (r *responseRecorder) flush() {
+	dst := r.ResponseWriter.Header()
+	for k, v := range r.header {
+		dst[k] = v
+	}
+	r.ResponseWriter.WriteHeader(r.status)
+	r.ResponseWriter.Write(r.body.Bytes())
+}
+
+// readBody reads and restores r.Body so downstream handlers
+// can still consume it after validation.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+// parseInt and parseBool are small helpers shared by the
+// parameter coercion logic in params.go.
+func parseInt(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }
+func parseBool(s string) (bool, error) { return strconv.ParseBool(s) }
@@ -0,0 +1,144 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wI2L/fizz/openapi"
+)
+
+func testAPI() *openapi.OpenAPI {
+	return &openapi.OpenAPI{
+		Paths: openapi.Paths{
+			"/pets/{id}": &openapi.PathItem{
+				GET: &openapi.Operation{
+					Parameters: []*openapi.ParameterOrRef{
+						{Parameter: &openapi.Parameter{Name: "id", In: "path", Required: true,
+							Schema: &openapi.SchemaOrRef{Schema: &openapi.Schema{Type: "integer"}}}},
+						{Parameter: &openapi.Parameter{Name: "verbose", In: "query",
+							Schema: &openapi.SchemaOrRef{Schema: &openapi.Schema{Type: "boolean"}}}},
+					},
+					Responses: openapi.Responses{
+						"200": &openapi.ResponseOrRef{Response: &openapi.Response{
+							Content: map[string]*openapi.MediaType{
+								"application/json": {Schema: &openapi.SchemaOrRef{Schema: &openapi.Schema{
+									Type:     "object",
+									Required: []string{"name"},
+									Properties: map[string]*openapi.SchemaOrRef{
+										"name": {Schema: &openapi.Schema{Type: "string"}},
+									},
+								}}},
+							},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// TestCompilePath tests that a path template with a named
+// placeholder compiles without panicking, matches a request
+// path, and extracts the placeholder's name and value.
+func TestCompilePath(t *testing.T) {
+	pattern, names := compilePath("/pets/{id}")
+	assert.Equal(t, []string{"id"}, names)
+
+	m := pattern.FindStringSubmatch("/pets/42")
+	if assert.NotNil(t, m) {
+		assert.Equal(t, "42", m[1])
+	}
+	assert.Nil(t, pattern.FindStringSubmatch("/pets/42/toys"))
+}
+
+// TestValidateParamsRejectsUnknownQuery tests that a query
+// parameter not declared on the operation is rejected.
+func TestValidateParamsRejectsUnknownQuery(t *testing.T) {
+	v, err := New(testAPI())
+	if err != nil {
+		t.Error(err)
+	}
+	called := false
+	h := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/pets/1?bogus=1", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// TestValidateParamsRejectsBadType tests that a path
+// parameter that cannot be coerced to its declared type is
+// rejected before the handler runs.
+func TestValidateParamsRejectsBadType(t *testing.T) {
+	v, err := New(testAPI())
+	if err != nil {
+		t.Error(err)
+	}
+	h := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/pets/not-a-number", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// TestValidateRequestBody tests that a JSON body missing a
+// required property is rejected.
+func TestValidateRequestBody(t *testing.T) {
+	api := testAPI()
+	api.Paths["/pets/{id}"].GET.RequestBody = &openapi.RequestBody{
+		Required: true,
+		Content: map[string]*openapi.MediaType{
+			"application/json": {Schema: &openapi.SchemaOrRef{Schema: &openapi.Schema{
+				Type:     "object",
+				Required: []string{"name"},
+			}}},
+		},
+	}
+	v, err := New(api)
+	if err != nil {
+		t.Error(err)
+	}
+	h := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/pets/1", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// TestValidateResponseBody tests that an invalid response
+// body is flagged through the X-OpenAPI-Validation-Error
+// header while the handler's status code is passed through.
+func TestValidateResponseBody(t *testing.T) {
+	v, err := New(testAPI(), WithResponseValidation())
+	if err != nil {
+		t.Error(err)
+	}
+	h := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`)) // missing required "name"
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/pets/1", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("X-OpenAPI-Validation-Error"))
+}